@@ -2,10 +2,15 @@ package main
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -16,67 +21,108 @@ type LogPayload struct {
 }
 
 type RequestPayload struct {
-	Action string    `json:"action"`
+	Action string     `json:"action"`
 	Log    LogPayload `json:"log"`
 }
 
+type HistogramBucket struct {
+	UpperBound time.Duration
+	Count      int
+}
+
 type BenchmarkResult struct {
-	Method      string
-	TotalTime   time.Duration
-	Requests    int
-	Successes   int
-	Failures    int
-	AvgLatency  time.Duration
-	MinLatency  time.Duration
-	MaxLatency  time.Duration
-	Throughput  float64 // requests per second
+	Method     string
+	TotalTime  time.Duration
+	Requests   int
+	Successes  int
+	Failures   int
+	AvgLatency time.Duration
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	P50        time.Duration
+	P90        time.Duration
+	P95        time.Duration
+	P99        time.Duration
+	P999       time.Duration
+	Histogram  []HistogramBucket
+	Throughput float64 // requests per second
 }
 
+var (
+	formatFlag = flag.String("format", "", "output format: json or csv (default: human-readable)")
+	rateFlag   = flag.Int("rate", 0, "target requests/sec per goroutine; 0 runs as fast as possible (closed-loop)")
+)
+
 func main() {
+	flag.Parse()
+	args := flag.Args()
+
 	brokerURL := "http://broker-service"
-	if len(os.Args) > 1 {
-		brokerURL = os.Args[1]
+	if len(args) > 0 {
+		brokerURL = args[0]
 	}
 
 	requests := 100
-	if len(os.Args) > 2 {
-		fmt.Sscanf(os.Args[2], "%d", &requests)
+	if len(args) > 1 {
+		fmt.Sscanf(args[1], "%d", &requests)
 	}
 
 	concurrency := 10
-	if len(os.Args) > 3 {
-		fmt.Sscanf(os.Args[3], "%d", &concurrency)
+	if len(args) > 2 {
+		fmt.Sscanf(args[2], "%d", &concurrency)
 	}
 
-	fmt.Printf("Benchmarking broker->logger communication methods\n")
-	fmt.Printf("Broker URL: %s\n", brokerURL)
-	fmt.Printf("Requests: %d\n", requests)
-	fmt.Printf("Concurrency: %d\n\n", concurrency)
+	humanOutput := *formatFlag == ""
+
+	if humanOutput {
+		fmt.Printf("Benchmarking broker->logger communication methods\n")
+		fmt.Printf("Broker URL: %s\n", brokerURL)
+		fmt.Printf("Requests: %d\n", requests)
+		fmt.Printf("Concurrency: %d\n", concurrency)
+		if *rateFlag > 0 {
+			fmt.Printf("Rate: %d req/s per goroutine (constant throughput)\n", *rateFlag)
+		}
+		fmt.Println()
 
-	// Warm up
-	fmt.Println("Warming up...")
+		// Warm up
+		fmt.Println("Warming up...")
+	}
 	warmup(brokerURL)
 
-	// Benchmark each method
-	fmt.Println("\n=== Benchmarking HTTP (Direct) ===")
-	httpResult := benchmarkHTTP(brokerURL, requests, concurrency)
-	printResult(httpResult)
-
-	fmt.Println("\n=== Benchmarking RabbitMQ ===")
-	rabbitResult := benchmarkRabbitMQ(brokerURL, requests, concurrency)
-	printResult(rabbitResult)
-
-	fmt.Println("\n=== Benchmarking gRPC ===")
-	grpcResult := benchmarkGRPC(brokerURL, requests, concurrency)
-	printResult(grpcResult)
-
-	// Summary
-	fmt.Println("\n=== SUMMARY ===")
-	fmt.Printf("%-15s %12s %10s %12s %12s\n", "Method", "Avg Latency", "Throughput", "Success", "Failure")
-	fmt.Println("----------------------------------------------------------------")
-	printSummary(httpResult)
-	printSummary(rabbitResult)
-	printSummary(grpcResult)
+	results := []BenchmarkResult{
+		runBenchmark(humanOutput, "HTTP (Direct)", func() BenchmarkResult { return benchmarkHTTP(brokerURL, requests, concurrency, *rateFlag) }),
+		runBenchmark(humanOutput, "RabbitMQ", func() BenchmarkResult { return benchmarkRabbitMQ(brokerURL, requests, concurrency, *rateFlag) }),
+		runBenchmark(humanOutput, "gRPC", func() BenchmarkResult { return benchmarkGRPC(brokerURL, requests, concurrency, *rateFlag) }),
+		runBenchmark(humanOutput, "gRPC Stream", func() BenchmarkResult { return benchmarkGRPCStream(brokerURL, requests, concurrency, *rateFlag) }),
+	}
+
+	switch *formatFlag {
+	case "json":
+		printJSON(results)
+	case "csv":
+		printCSV(results)
+	default:
+		fmt.Println("\n=== SUMMARY ===")
+		fmt.Printf("%-15s %12s %10s %12s %12s\n", "Method", "Avg Latency", "Throughput", "Success", "Failure")
+		fmt.Println("----------------------------------------------------------------")
+		for _, result := range results {
+			printSummary(result)
+		}
+	}
+}
+
+func runBenchmark(humanOutput bool, label string, bench func() BenchmarkResult) BenchmarkResult {
+	if humanOutput {
+		fmt.Printf("\n=== Benchmarking %s ===\n", label)
+	}
+
+	result := bench()
+
+	if humanOutput {
+		printResult(result)
+	}
+
+	return result
 }
 
 func warmup(brokerURL string) {
@@ -96,7 +142,23 @@ func warmup(brokerURL string) {
 	time.Sleep(100 * time.Millisecond)
 }
 
-func benchmarkHTTP(brokerURL string, totalRequests, concurrency int) BenchmarkResult {
+// newPacer returns a function that, when rate > 0, blocks until the next
+// tick so a goroutine sends at a constant N req/s instead of firing as fast
+// as possible. Closed-loop ("as fast as possible") benchmarks systematically
+// understate queueing latency under load, so -rate opts into an open-loop
+// constant-throughput model instead.
+func newPacer(rate int) func() {
+	if rate <= 0 {
+		return func() {}
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	return func() {
+		<-ticker.C
+	}
+}
+
+func benchmarkHTTP(brokerURL string, totalRequests, concurrency, rate int) BenchmarkResult {
 	start := time.Now()
 	latencies := make([]time.Duration, 0, totalRequests)
 	var mu sync.Mutex
@@ -111,8 +173,11 @@ func benchmarkHTTP(brokerURL string, totalRequests, concurrency int) BenchmarkRe
 		go func() {
 			defer wg.Done()
 			client := &http.Client{Timeout: 5 * time.Second}
+			wait := newPacer(rate)
 
 			for j := 0; j < requestsPerGoroutine; j++ {
+				wait()
+
 				payload := RequestPayload{
 					Action: "log",
 					Log: LogPayload{
@@ -154,7 +219,7 @@ func benchmarkHTTP(brokerURL string, totalRequests, concurrency int) BenchmarkRe
 	return calculateResult("HTTP", totalTime, latencies, successes, failures, totalRequests)
 }
 
-func benchmarkRabbitMQ(brokerURL string, totalRequests, concurrency int) BenchmarkResult {
+func benchmarkRabbitMQ(brokerURL string, totalRequests, concurrency, rate int) BenchmarkResult {
 	start := time.Now()
 	latencies := make([]time.Duration, 0, totalRequests)
 	var mu sync.Mutex
@@ -169,8 +234,11 @@ func benchmarkRabbitMQ(brokerURL string, totalRequests, concurrency int) Benchma
 		go func() {
 			defer wg.Done()
 			client := &http.Client{Timeout: 5 * time.Second}
+			wait := newPacer(rate)
 
 			for j := 0; j < requestsPerGoroutine; j++ {
+				wait()
+
 				payload := RequestPayload{
 					Action: "log",
 					Log: LogPayload{
@@ -212,7 +280,7 @@ func benchmarkRabbitMQ(brokerURL string, totalRequests, concurrency int) Benchma
 	return calculateResult("RabbitMQ", totalTime, latencies, successes, failures, totalRequests)
 }
 
-func benchmarkGRPC(brokerURL string, totalRequests, concurrency int) BenchmarkResult {
+func benchmarkGRPC(brokerURL string, totalRequests, concurrency, rate int) BenchmarkResult {
 	start := time.Now()
 	latencies := make([]time.Duration, 0, totalRequests)
 	var mu sync.Mutex
@@ -227,8 +295,11 @@ func benchmarkGRPC(brokerURL string, totalRequests, concurrency int) BenchmarkRe
 		go func() {
 			defer wg.Done()
 			client := &http.Client{Timeout: 5 * time.Second}
+			wait := newPacer(rate)
 
 			for j := 0; j < requestsPerGoroutine; j++ {
+				wait()
+
 				payload := RequestPayload{
 					Action: "log",
 					Log: LogPayload{
@@ -270,6 +341,67 @@ func benchmarkGRPC(brokerURL string, totalRequests, concurrency int) BenchmarkRe
 	return calculateResult("gRPC", totalTime, latencies, successes, failures, totalRequests)
 }
 
+func benchmarkGRPCStream(brokerURL string, totalRequests, concurrency, rate int) BenchmarkResult {
+	start := time.Now()
+	latencies := make([]time.Duration, 0, totalRequests)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	successes := 0
+	failures := 0
+
+	requestsPerGoroutine := totalRequests / concurrency
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client := &http.Client{Timeout: 5 * time.Second}
+			wait := newPacer(rate)
+
+			for j := 0; j < requestsPerGoroutine; j++ {
+				wait()
+
+				payload := RequestPayload{
+					Action: "log",
+					Log: LogPayload{
+						Name: fmt.Sprintf("grpc-stream-test-%d", j),
+						Data: fmt.Sprintf("gRPC stream benchmark data %d", j),
+					},
+				}
+
+				jsonData, _ := json.Marshal(payload)
+				reqStart := time.Now()
+				req, err := http.NewRequest("POST", brokerURL+"/log-grpc-stream", bytes.NewBuffer(jsonData))
+				if err != nil {
+					mu.Lock()
+					failures++
+					mu.Unlock()
+					continue
+				}
+
+				req.Header.Set("Content-Type", "application/json")
+				resp, err := client.Do(req)
+				latency := time.Since(reqStart)
+
+				mu.Lock()
+				if err != nil || resp == nil || resp.StatusCode != http.StatusAccepted {
+					failures++
+				} else {
+					successes++
+					latencies = append(latencies, latency)
+					resp.Body.Close()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	totalTime := time.Since(start)
+
+	return calculateResult("gRPC Stream", totalTime, latencies, successes, failures, totalRequests)
+}
+
 func calculateResult(method string, totalTime time.Duration, latencies []time.Duration, successes, failures, totalRequests int) BenchmarkResult {
 	if len(latencies) == 0 {
 		return BenchmarkResult{
@@ -298,6 +430,10 @@ func calculateResult(method string, totalTime time.Duration, latencies []time.Du
 	avgLatency := sum / time.Duration(len(latencies))
 	throughput := float64(successes) / totalTime.Seconds()
 
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
 	return BenchmarkResult{
 		Method:     method,
 		TotalTime:  totalTime,
@@ -307,10 +443,57 @@ func calculateResult(method string, totalTime time.Duration, latencies []time.Du
 		AvgLatency: avgLatency,
 		MinLatency: min,
 		MaxLatency: max,
+		P50:        percentile(sorted, 50),
+		P90:        percentile(sorted, 90),
+		P95:        percentile(sorted, 95),
+		P99:        percentile(sorted, 99),
+		P999:       percentile(sorted, 99.9),
+		Histogram:  histogram(sorted, 10),
 		Throughput: throughput,
 	}
 }
 
+// percentile returns the p-th percentile of an already ascending-sorted
+// slice of latencies, taking the element at index floor(p/100 * (n-1)).
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	idx := int(p / 100 * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// histogram buckets sorted latencies into equal-width buckets between the
+// min and max observed latency, for a coarse view of the tail shape.
+func histogram(sorted []time.Duration, buckets int) []HistogramBucket {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	min, max := sorted[0], sorted[len(sorted)-1]
+	width := (max - min) / time.Duration(buckets)
+	if width == 0 {
+		return []HistogramBucket{{UpperBound: max, Count: len(sorted)}}
+	}
+
+	result := make([]HistogramBucket, buckets)
+	for i := range result {
+		result[i].UpperBound = min + time.Duration(i+1)*width
+	}
+	result[buckets-1].UpperBound = max
+
+	for _, lat := range sorted {
+		idx := int((lat - min) / width)
+		if idx >= buckets {
+			idx = buckets - 1
+		}
+		result[idx].Count++
+	}
+
+	return result
+}
+
 func printResult(result BenchmarkResult) {
 	fmt.Printf("Total Time:     %v\n", result.TotalTime)
 	fmt.Printf("Requests:       %d\n", result.Requests)
@@ -320,7 +503,72 @@ func printResult(result BenchmarkResult) {
 		fmt.Printf("Avg Latency:    %v\n", result.AvgLatency)
 		fmt.Printf("Min Latency:    %v\n", result.MinLatency)
 		fmt.Printf("Max Latency:    %v\n", result.MaxLatency)
+		fmt.Printf("p50/p90/p95/p99/p99.9: %v / %v / %v / %v / %v\n",
+			result.P50, result.P90, result.P95, result.P99, result.P999)
 		fmt.Printf("Throughput:     %.2f req/s\n", result.Throughput)
+		fmt.Print(asciiHistogram(result.Histogram))
+	}
+}
+
+// asciiHistogram renders latency buckets as a coarse bar chart so tail
+// shape is visible at a glance without reaching for external tooling.
+func asciiHistogram(buckets []HistogramBucket) string {
+	if len(buckets) == 0 {
+		return ""
+	}
+
+	maxCount := 0
+	for _, b := range buckets {
+		if b.Count > maxCount {
+			maxCount = b.Count
+		}
+	}
+	if maxCount == 0 {
+		return ""
+	}
+
+	const barWidth = 40
+	var sb strings.Builder
+	sb.WriteString("Latency histogram:\n")
+	for _, b := range buckets {
+		bars := b.Count * barWidth / maxCount
+		sb.WriteString(fmt.Sprintf("  <= %-10v %s %d\n", b.UpperBound, strings.Repeat("#", bars), b.Count))
+	}
+	return sb.String()
+}
+
+func printJSON(results []BenchmarkResult) {
+	out, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal results: %v\n", err)
+		return
+	}
+	fmt.Println(string(out))
+}
+
+func printCSV(results []BenchmarkResult) {
+	w := csv.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	w.Write([]string{"method", "requests", "successes", "failures", "throughput_rps",
+		"avg_ms", "min_ms", "max_ms", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "p999_ms"})
+
+	for _, r := range results {
+		w.Write([]string{
+			r.Method,
+			strconv.Itoa(r.Requests),
+			strconv.Itoa(r.Successes),
+			strconv.Itoa(r.Failures),
+			strconv.FormatFloat(r.Throughput, 'f', 2, 64),
+			strconv.FormatFloat(float64(r.AvgLatency.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatFloat(float64(r.MinLatency.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatFloat(float64(r.MaxLatency.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatFloat(float64(r.P50.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatFloat(float64(r.P90.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatFloat(float64(r.P95.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatFloat(float64(r.P99.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatFloat(float64(r.P999.Microseconds())/1000, 'f', 3, 64),
+		})
 	}
 }
 
@@ -341,4 +589,3 @@ func printSummary(result BenchmarkResult) {
 			result.Failures)
 	}
 }
-
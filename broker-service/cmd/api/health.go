@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthGRPCPort   = "50000"
+	healthPollPeriod = 10 * time.Second
+)
+
+// downstreamServices lists everything the broker depends on. Each is polled
+// and reported independently so operators can tell "broker up, logger down"
+// apart from a total outage.
+var downstreamServices = []string{"logger", "authentication", "mailer", "rabbitmq"}
+
+// startHealthServer registers the standard grpc.health.v1.Health service,
+// serves it on its own gRPC listener, and kicks off the background poller
+// that keeps its serving statuses up to date.
+func (app *Config) startHealthServer() {
+	app.HealthServer = health.NewServer()
+	app.HealthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	for _, name := range downstreamServices {
+		app.HealthServer.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", healthGRPCPort))
+	if err != nil {
+		log.Printf("health: failed to listen on :%s: %v\n", healthGRPCPort, err)
+	} else {
+		grpcServer := grpc.NewServer()
+		grpc_health_v1.RegisterHealthServer(grpcServer, app.HealthServer)
+
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Printf("health: grpc server stopped: %v\n", err)
+			}
+		}()
+	}
+
+	// pollDownstreams only touches app.HealthServer's in-memory statuses, not
+	// the gRPC listener above, so /healthz and /status must keep working off
+	// HTTP even if the dedicated gRPC health port failed to bind.
+	go app.pollDownstreams()
+}
+
+func (app *Config) pollDownstreams() {
+	ticker := time.NewTicker(healthPollPeriod)
+	defer ticker.Stop()
+
+	app.checkDownstreams()
+	for range ticker.C {
+		app.checkDownstreams()
+	}
+}
+
+func (app *Config) checkDownstreams() {
+	app.HealthServer.SetServingStatus("logger", servingStatus(app.checkLoggerHealth()))
+	app.HealthServer.SetServingStatus("authentication", servingStatus(app.checkHTTPHealth("http://authentication-service/")))
+	app.HealthServer.SetServingStatus("mailer", servingStatus(app.checkHTTPHealth("http://mail-service/")))
+	app.HealthServer.SetServingStatus("rabbitmq", servingStatus(app.checkRabbitHealth()))
+}
+
+func servingStatus(ok bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if ok {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
+
+func (app *Config) checkLoggerHealth() bool {
+	if app.GRPCConn == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	resp, err := grpc_health_v1.NewHealthClient(app.GRPCConn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return false
+	}
+	return resp.Status == grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+func (app *Config) checkHTTPHealth(url string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := app.HTTPClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+func (app *Config) checkRabbitHealth() bool {
+	return app.RabbitPublisher != nil && app.RabbitPublisher.Healthy()
+}
+
+// healthzHandler reports the broker's own status (the empty service name),
+// which is set to SERVING at startup and is not derived from the downstream
+// checks.
+func (app *Config) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	app.writeHealth(w, "")
+}
+
+// healthzServiceHandler reports the status of a single downstream service,
+// e.g. GET /healthz/logger.
+func (app *Config) healthzServiceHandler(w http.ResponseWriter, r *http.Request) {
+	app.writeHealth(w, chi.URLParam(r, "service"))
+}
+
+func (app *Config) writeHealth(w http.ResponseWriter, service string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	resp, err := app.HealthServer.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		app.errorJSON(w, err, http.StatusNotFound)
+		return
+	}
+
+	status := http.StatusOK
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		status = http.StatusServiceUnavailable
+	}
+
+	app.writeJSON(w, status, jsonResponse{
+		Error:   resp.Status != grpc_health_v1.HealthCheckResponse_SERVING,
+		Message: resp.Status.String(),
+	})
+}
+
+// statusHandler reports every downstream service's status in one JSON
+// response, so operators don't have to poll /healthz/{service} in a loop.
+func (app *Config) statusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	report := make(map[string]string, len(downstreamServices))
+	for _, name := range downstreamServices {
+		resp, err := app.HealthServer.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: name})
+		if err != nil {
+			report[name] = "unknown"
+			continue
+		}
+		report[name] = resp.Status.String()
+	}
+
+	data := map[string]interface{}{"services": report}
+	if app.LogStreamer != nil {
+		data["log_stream"] = app.LogStreamer.stats()
+	}
+
+	app.writeJSON(w, http.StatusOK, jsonResponse{
+		Error:   false,
+		Message: "broker is up",
+		Data:    data,
+	})
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type jsonResponse struct {
+	Error   bool        `json:"error"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (app *Config) writeJSON(w http.ResponseWriter, status int, data interface{}) error {
+	out, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(out)
+	return err
+}
+
+func (app *Config) errorJSON(w http.ResponseWriter, err error, status int) {
+	payload := jsonResponse{
+		Error:   true,
+		Message: err.Error(),
+	}
+
+	app.writeJSON(w, status, payload)
+}
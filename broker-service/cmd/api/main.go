@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
@@ -9,31 +10,53 @@ import (
 	"time"
 
 	"broker/logs"
+	"broker/observability"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
 )
 
 const webPort = "80"
 
 type Config struct {
-	Rabbit     *amqp.Connection
-	GRPCClient logs.LogServiceClient
-	GRPCConn   *grpc.ClientConn
-	HTTPClient *http.Client
+	RabbitPublisher *RabbitPublisher
+	GRPCClient      logs.LogServiceClient
+	GRPCConn        *grpc.ClientConn
+	HTTPClient      *http.Client
+	HealthServer    *health.Server
+	LogStreamer     *logStreamer
 }
 
 func main() {
+	shutdownTracing, err := observability.Init(context.Background(), "broker-service")
+	if err != nil {
+		log.Printf("Warning: failed to initialize observability: %v\n", err)
+	} else {
+		defer shutdownTracing(context.Background())
+	}
+
 	rabbitconn, err := connect()
 	if err != nil {
 		log.Println(err)
 		os.Exit(1)
 	}
-	defer rabbitconn.Close()
 
-	// Connect to gRPC service (reuse connection)
-	grpcConn, err := grpc.Dial("logger-service:50001", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	rabbitPublisher, err := NewRabbitPublisher(rabbitconn)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+	// Connect to gRPC service (reuse connection), instrumented so a trace
+	// started in the HTTP handler continues across the wire to the logger.
+	grpcConn, err := grpc.Dial("logger-service:50001",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
 		log.Printf("Warning: Failed to connect to gRPC service: %v\n", err)
 		log.Println("gRPC features will be unavailable")
@@ -62,10 +85,15 @@ func main() {
 	}
 
 	app := Config{
-		Rabbit:     rabbitconn,
-		GRPCClient: grpcClient,
-		GRPCConn:   grpcConn,
-		HTTPClient: httpClient,
+		RabbitPublisher: rabbitPublisher,
+		GRPCClient:      grpcClient,
+		GRPCConn:        grpcConn,
+		HTTPClient:      httpClient,
+	}
+
+	app.startHealthServer()
+	if grpcClient != nil {
+		app.LogStreamer = app.startLogStreamers()
 	}
 
 	log.Printf("Starting broker service on port %s\n", webPort)
@@ -73,7 +101,7 @@ func main() {
 	// define http server
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", webPort),
-		Handler: app.routes(),
+		Handler: otelhttp.NewHandler(app.routes(), "broker-service"),
 	}
 
 	// start the server
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+
+	"broker/observability"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+)
+
+func (app *Config) routes() http.Handler {
+	mux := chi.NewRouter()
+
+	mux.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   []string{"https://*", "http://*"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"Link"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}))
+
+	mux.Use(middleware.Heartbeat("/ping"))
+
+	mux.Post("/log-http", app.logHTTP)
+	mux.Post("/log-rabbit", app.logRabbit)
+	mux.Post("/log-grpc-stream", app.logGRPCStream)
+	app.registerGRPCRoutes(mux)
+
+	mux.Get("/healthz", app.healthzHandler)
+	mux.Get("/healthz/{service}", app.healthzServiceHandler)
+	mux.Get("/status", app.statusHandler)
+	mux.Handle("/metrics", observability.MetricsHandler())
+
+	return mux
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"broker/logs"
+	"broker/observability"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	defaultStreamWorkers   = 4
+	defaultStreamQueueSize = 1000
+)
+
+var (
+	errStreamMissingLogField = errors.New("missing \"log\" field")
+	errStreamQueueFull       = errors.New("log stream queue is full")
+)
+
+// dropMode controls what a logStreamer does when its queue is full.
+type dropMode string
+
+const (
+	dropOldest dropMode = "drop-oldest"
+	blockMode  dropMode = "block"
+)
+
+// logStreamer owns the bounded queue and worker pool that feed a persistent
+// client-streaming WriteLogStream RPC. The HTTP handler only ever touches
+// enqueue; every worker independently drains the same channel into its own
+// stream and reconnects using the exponential backoff pattern from connect().
+// dropped/queued are kept as plain counters for the /status endpoint and
+// mirrored into queuedCounter/droppedCounter so the same numbers show up at
+// /metrics for anyone scraping Prometheus instead of polling /status.
+type logStreamer struct {
+	app            *Config
+	queue          chan *logs.Log
+	mode           dropMode
+	dropped        uint64
+	queued         uint64
+	queuedCounter  metric.Int64Counter
+	droppedCounter metric.Int64Counter
+}
+
+func newLogStreamer(app *Config) *logStreamer {
+	size := envInt("LOG_STREAM_QUEUE_SIZE", defaultStreamQueueSize)
+	mode := dropMode(os.Getenv("LOG_STREAM_MODE"))
+	if mode != dropOldest {
+		mode = blockMode
+	}
+
+	queuedCounter, err := observability.Meter().Int64Counter("broker_log_stream_queued_total",
+		metric.WithDescription("log entries accepted onto the gRPC log stream queue"))
+	if err != nil {
+		log.Printf("log stream: failed to create queued counter: %v\n", err)
+	}
+
+	droppedCounter, err := observability.Meter().Int64Counter("broker_log_stream_dropped_total",
+		metric.WithDescription("log entries dropped because the gRPC log stream queue was full"))
+	if err != nil {
+		log.Printf("log stream: failed to create dropped counter: %v\n", err)
+	}
+
+	return &logStreamer{
+		app:            app,
+		queue:          make(chan *logs.Log, size),
+		mode:           mode,
+		queuedCounter:  queuedCounter,
+		droppedCounter: droppedCounter,
+	}
+}
+
+func envInt(key string, def int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// startLogStreamers launches the worker pool and returns the streamer the
+// HTTP layer enqueues onto.
+func (app *Config) startLogStreamers() *logStreamer {
+	streamer := newLogStreamer(app)
+	workers := envInt("LOG_STREAM_WORKERS", defaultStreamWorkers)
+
+	for i := 0; i < workers; i++ {
+		go streamer.run()
+	}
+
+	return streamer
+}
+
+// enqueue applies the configured flow-control mode and reports whether the
+// entry was accepted.
+func (s *logStreamer) enqueue(l *logs.Log) bool {
+	select {
+	case s.queue <- l:
+		s.recordQueued()
+		return true
+	default:
+	}
+
+	if s.mode == blockMode {
+		s.queue <- l
+		s.recordQueued()
+		return true
+	}
+
+	// drop-oldest: make room by discarding the head of the queue, then retry once.
+	select {
+	case <-s.queue:
+		s.recordDropped()
+	default:
+	}
+
+	select {
+	case s.queue <- l:
+		s.recordQueued()
+		return true
+	default:
+		s.recordDropped()
+		return false
+	}
+}
+
+func (s *logStreamer) recordQueued() {
+	atomic.AddUint64(&s.queued, 1)
+	s.queuedCounter.Add(context.Background(), 1)
+}
+
+func (s *logStreamer) recordDropped() {
+	atomic.AddUint64(&s.dropped, 1)
+	s.droppedCounter.Add(context.Background(), 1)
+}
+
+func (s *logStreamer) run() {
+	var counts float64
+	for {
+		stream, err := s.app.GRPCClient.WriteLogStream(context.Background())
+		if err != nil {
+			backOff := time.Duration(math.Pow(counts, 2)) * time.Second
+			log.Printf("log stream: failed to open stream, backing off %v: %v\n", backOff, err)
+			time.Sleep(backOff)
+			counts++
+			continue
+		}
+		counts = 0
+
+		if err := s.drain(stream); err != nil {
+			log.Printf("log stream: stream error, reconnecting: %v\n", err)
+		}
+	}
+}
+
+// drain feeds queued entries into stream until Send fails, requeueing the
+// entry that failed so it is retried once the stream is reconnected, or
+// counting it as dropped if the queue is already full.
+func (s *logStreamer) drain(stream logs.LogService_WriteLogStreamClient) error {
+	for l := range s.queue {
+		if err := stream.Send(&logs.LogRequest{Log: l}); err != nil {
+			s.requeue(l)
+			return err
+		}
+	}
+	return nil
+}
+
+// requeue puts a message that failed to send back on the queue without
+// blocking, regardless of the configured drop mode. This runs on the same
+// goroutine responsible for draining the queue, so it must never be allowed
+// to stall on a full queue the way enqueue's blockMode path can — that would
+// leave the queue permanently full with nobody left to drain it.
+func (s *logStreamer) requeue(l *logs.Log) {
+	select {
+	case s.queue <- l:
+	default:
+		s.recordDropped()
+	}
+}
+
+func (s *logStreamer) stats() map[string]uint64 {
+	return map[string]uint64{
+		"queued":  atomic.LoadUint64(&s.queued),
+		"dropped": atomic.LoadUint64(&s.dropped),
+		"depth":   uint64(len(s.queue)),
+	}
+}
+
+// logGRPCStream enqueues the log entry for asynchronous delivery over the
+// persistent gRPC stream and returns immediately instead of waiting on a
+// downstream round trip.
+func (app *Config) logGRPCStream(w http.ResponseWriter, r *http.Request) {
+	if app.LogStreamer == nil {
+		app.errorJSON(w, errors.New("log streaming unavailable"), http.StatusServiceUnavailable)
+		return
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	field, ok := raw["log"]
+	if !ok {
+		app.errorJSON(w, errStreamMissingLogField, http.StatusBadRequest)
+		return
+	}
+
+	var l logs.Log
+	if err := json.Unmarshal(field, &l); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if !app.LogStreamer.enqueue(&l) {
+		app.errorJSON(w, errStreamQueueFull, http.StatusServiceUnavailable)
+		return
+	}
+
+	app.writeJSON(w, http.StatusAccepted, jsonResponse{
+		Error:   false,
+		Message: "queued for streaming",
+	})
+}
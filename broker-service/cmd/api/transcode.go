@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"broker/logs"
+	"broker/observability"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMapping declaratively binds an HTTP route to a downstream gRPC method,
+// analogous to a google.api.http annotation on a .proto rpc. Invoke unmarshals
+// the JSON found under BodyField and performs the actual gRPC call.
+type grpcMapping struct {
+	GRPCMethod        string // service and method name, e.g. "LogService.WriteLog"
+	DownstreamService string // name of the service actually handling the call
+	HTTPMethod        string
+	HTTPPath          string
+	BodyField         string
+	Invoke            func(ctx context.Context, app *Config, body json.RawMessage) (interface{}, error)
+}
+
+// grpcRoutes lists every downstream gRPC method the broker exposes over HTTP.
+// Adding a new transcoded endpoint is a matter of appending an entry here
+// rather than writing a bespoke handler.
+var grpcRoutes = []grpcMapping{
+	{
+		GRPCMethod:        "LogService.WriteLog",
+		DownstreamService: "logger",
+		HTTPMethod:        http.MethodPost,
+		HTTPPath:          "/log-grpc",
+		BodyField:         "log",
+		Invoke: func(ctx context.Context, app *Config, body json.RawMessage) (interface{}, error) {
+			var l logs.Log
+			if err := json.Unmarshal(body, &l); err != nil {
+				return nil, err
+			}
+
+			return app.GRPCClient.WriteLog(ctx, &logs.LogRequest{Log: &l})
+		},
+	},
+}
+
+// registerGRPCRoutes wires every entry in grpcRoutes onto the router.
+func (app *Config) registerGRPCRoutes(mux chi.Router) {
+	for _, mapping := range grpcRoutes {
+		mux.MethodFunc(mapping.HTTPMethod, mapping.HTTPPath, app.transcodeHandler(mapping))
+	}
+}
+
+// transcodeHandler turns a declarative grpcMapping into an http.HandlerFunc:
+// decode the body, pull out BodyField, invoke the downstream gRPC method, and
+// relay the result (or a translated gRPC status) back as JSON.
+func (app *Config) transcodeHandler(mapping grpcMapping) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if app.GRPCClient == nil {
+			app.errorJSON(w, errors.New("grpc client unavailable"), http.StatusServiceUnavailable)
+			return
+		}
+
+		var raw map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			app.errorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+
+		field, ok := raw[mapping.BodyField]
+		if !ok {
+			app.errorJSON(w, fmt.Errorf("missing %q field", mapping.BodyField), http.StatusBadRequest)
+			return
+		}
+
+		ctx, span := observability.Tracer().Start(r.Context(), mapping.GRPCMethod,
+			trace.WithAttributes(
+				attribute.String("action", "log"),
+				attribute.String("downstream.service", mapping.DownstreamService),
+				attribute.Int("payload.size", len(field)),
+			),
+		)
+		defer span.End()
+
+		result, err := mapping.Invoke(ctx, app, field)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if st, ok := status.FromError(err); ok {
+				app.errorJSON(w, errors.New(st.Message()), http.StatusBadGateway)
+				return
+			}
+			app.errorJSON(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		app.writeJSON(w, http.StatusAccepted, jsonResponse{
+			Error:   false,
+			Message: fmt.Sprintf("invoked %s", mapping.GRPCMethod),
+			Data:    result,
+		})
+	}
+}
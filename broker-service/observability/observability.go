@@ -0,0 +1,115 @@
+// Package observability installs OpenTelemetry tracing and metrics for the
+// broker and exposes the handful of helpers its transports need: a tracer,
+// a context propagation carrier for AMQP headers, and a Prometheus scrape
+// handler for /metrics.
+package observability
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	tracerName = "broker"
+	meterName  = "broker"
+)
+
+var (
+	tracer = otel.Tracer(tracerName)
+	meter  = otel.Meter(meterName)
+)
+
+// Tracer returns the broker's package-wide tracer. Safe to call before Init;
+// spans just won't go anywhere until a real TracerProvider is installed.
+func Tracer() trace.Tracer {
+	return tracer
+}
+
+// Meter returns the broker's package-wide meter. Safe to call before Init;
+// instruments just won't record anywhere until a real MeterProvider is
+// installed, which is also why transports build their instruments lazily
+// rather than at package init time.
+func Meter() metric.Meter {
+	return meter
+}
+
+// Init installs global trace and metric providers for serviceName and
+// returns a shutdown func that should be deferred to flush pending spans.
+func Init(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	traceExporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tracer = tracerProvider.Tracer(tracerName)
+
+	metricExporter, err := prometheus.New()
+	if err != nil {
+		return nil, err
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(metricExporter),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetMeterProvider(meterProvider)
+	meter = meterProvider.Meter(meterName)
+
+	return tracerProvider.Shutdown, nil
+}
+
+// MetricsHandler serves the Prometheus scrape endpoint backed by whatever
+// MeterProvider Init installed.
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// MapCarrier adapts a plain map to propagation.TextMapCarrier so a W3C
+// traceparent can be injected into (or extracted from) AMQP message headers,
+// which share the same map[string]interface{} shape as amqp.Table.
+type MapCarrier map[string]interface{}
+
+func (c MapCarrier) Get(key string) string {
+	if v, ok := c[key]; ok {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func (c MapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c MapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}